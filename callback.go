@@ -0,0 +1,57 @@
+package goparse
+
+import "strings"
+
+// The WithCallback variants below register a flag, value flag, positional parameter, or
+// list parameter exactly like their plain counterparts, except that callback is invoked
+// with the value the instant it is consumed during parsing. A non-nil error from callback
+// aborts parsing immediately and is added to the error slice returned by ParseArgs, which
+// MustParseArgs prints before exiting.
+
+func (p *Parser) AddFlagWithCallback(longName string, shortName rune, description string, setByDefault bool, callback func(value any) error) {
+	c := flagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		setByDefault: setByDefault,
+		callback:     callback,
+	}
+
+	p.flagParams = append(p.flagParams, c)
+}
+
+func (p *Parser) AddValueFlagWithCallback(longName string, shortName rune, description string, valueName string, defaultValue string, callback func(value any) error) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(valueName),
+		defaultValue: defaultValue,
+		callback:     callback,
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
+func (p *Parser) AddParameterWithCallback(name string, description string, callback func(value any) error) {
+	c := positionalParam{
+		name:        name,
+		description: description,
+		options:     []paramOption{},
+		callback:    callback,
+	}
+
+	p.positionalParams = append(p.positionalParams, c)
+}
+
+func (p *Parser) SetListParameterWithCallback(name string, description string, min int, callback func(value any) error) {
+	c := positionalParam{
+		name:        name,
+		description: description,
+		options:     []paramOption{},
+		minCount:    min,
+		callback:    callback,
+	}
+
+	p.listParam = &c
+}
@@ -0,0 +1,80 @@
+package goparse
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAddFlagWithCallbackInvoked(t *testing.T) {
+	p := NewParser()
+
+	var got any
+
+	p.AddFlagWithCallback("verbose", 'v', "be verbose", false, func(value any) error {
+		got = value
+		return nil
+	})
+
+	_, errs := p.parseArgs([]string{"--verbose"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if got != true {
+		t.Errorf("expected callback to receive true, got %v", got)
+	}
+}
+
+func TestAddFlagWithCallbackAbortsOnError(t *testing.T) {
+	p := NewParser()
+	p.AddFlagWithCallback("verbose", 'v', "be verbose", false, func(value any) error {
+		return fmt.Errorf("boom")
+	})
+	p.AddParameter("name", "a name")
+
+	values, errs := p.parseArgs([]string{"--verbose", "alice"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+	}
+
+	if _, exists := values["name"]; exists {
+		t.Errorf("expected parsing to abort before consuming further arguments")
+	}
+}
+
+func TestPassthroughSentinelCapturesRemainingArgs(t *testing.T) {
+	p := NewParser()
+	p.AddParameter("name", "a name")
+
+	values, errs := p.parseArgs([]string{"alice", "--", "-x", "--flag"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	passthrough, ok := values["--"].([]string)
+
+	if !ok || len(passthrough) != 2 || passthrough[0] != "-x" || passthrough[1] != "--flag" {
+		t.Errorf("expected passthrough [-x --flag], got %v", values["--"])
+	}
+}
+
+func TestMarshalRoundTripsPassthrough(t *testing.T) {
+	p := NewParser()
+	p.AddParameter("name", "a name")
+
+	command, err := p.Marshal("prog", map[string]any{
+		"name": "alice",
+		"--":   []string{"-x", "--flag"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if command != "prog alice -- -x --flag" {
+		t.Errorf("expected \"prog alice -- -x --flag\", got %q", command)
+	}
+}
@@ -0,0 +1,236 @@
+package goparse
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// completionSentinel is the hidden flag ParseArgs looks for to switch into completion
+// mode instead of normal argument parsing. Shell completion scripts generated by
+// GenerateCompletion invoke the program with this flag to ask for candidates.
+const completionSentinel = "--__complete"
+
+// runCompletionHook implements the --__complete runtime hook: it prints one completion
+// candidate per line for the word at the given index among args.
+func (p *Parser) runCompletionHook(hookArgs []string) {
+	if len(hookArgs) == 0 {
+		return
+	}
+
+	index, err := strconv.Atoi(hookArgs[0])
+
+	if err != nil {
+		return
+	}
+
+	for _, candidate := range p.completions(index, hookArgs[1:]) {
+		fmt.Println(candidate)
+	}
+}
+
+// completions walks the parser tree, following subparser selections already present in
+// args, and returns the candidates for completing the word at index. A nil return means
+// no flag, subparser, or choice candidate matches the word; the scripts written by
+// GenerateCompletion do not fall back to file-path completion in that case.
+func (p *Parser) completions(index int, args []string) []string {
+	current := ""
+
+	if index >= 0 && index < len(args) {
+		current = args[index]
+	}
+
+	active := p
+	pos := 0
+
+	for i := 0; i < index && i < len(args); i++ {
+		arg := args[i]
+
+		if strings.HasPrefix(arg, "--") {
+			longName := strings.TrimPrefix(arg, "--")
+
+			if active.isValueFlag(longName) {
+				i++
+			}
+
+			continue
+		}
+
+		if strings.HasPrefix(arg, "-") {
+			flags := strings.TrimPrefix(arg, "-")
+
+			for _, shortName := range flags {
+				if active.isValueFlagShort(shortName) {
+					i++
+				}
+			}
+
+			continue
+		}
+
+		if pos < len(active.positionalParams) {
+			param := active.positionalParams[pos]
+
+			if param.name == active.subparserArgument {
+				if subparser, ok := active.subparsers[arg]; ok {
+					active = &subparser
+					pos = 0
+					continue
+				}
+			}
+
+			pos++
+		}
+	}
+
+	if strings.HasPrefix(current, "-") {
+		return filterByPrefix(active.flagCandidates(), current)
+	}
+
+	if pos < len(active.positionalParams) {
+		param := active.positionalParams[pos]
+
+		if param.name == active.subparserArgument {
+			return filterByPrefix(active.subparserCandidates(), current)
+		}
+
+		if len(param.options) > 0 {
+			var names []string
+
+			for _, option := range param.options {
+				if !option.hidden {
+					names = append(names, option.name)
+				}
+			}
+
+			return filterByPrefix(names, current)
+		}
+	}
+
+	// No flag, subparser, or choice candidates apply here.
+	return nil
+}
+
+// isValueFlag reports whether longName identifies a value flag on p, meaning the token
+// that follows it on the command line is consumed as its value rather than completed.
+func (p *Parser) isValueFlag(longName string) bool {
+	for _, flagConfig := range p.valueFlagParams {
+		if flagConfig.longName == longName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isValueFlagShort reports whether shortName identifies a value flag on p, meaning the
+// token that follows it on the command line is consumed as its value rather than completed.
+func (p *Parser) isValueFlagShort(shortName rune) bool {
+	for _, flagConfig := range p.valueFlagParams {
+		if flagConfig.shortName == shortName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// flagCandidates returns every long flag on p formatted as a `--name` completion.
+func (p *Parser) flagCandidates() []string {
+	var candidates []string
+
+	for _, flagConfig := range p.flagParams {
+		candidates = append(candidates, "--"+flagConfig.longName)
+	}
+
+	for _, flagConfig := range p.valueFlagParams {
+		candidates = append(candidates, "--"+flagConfig.longName)
+	}
+
+	sort.Strings(candidates)
+
+	return candidates
+}
+
+func filterByPrefix(candidates []string, prefix string) []string {
+	if prefix == "" {
+		return candidates
+	}
+
+	var matches []string
+
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+
+	return matches
+}
+
+// GenerateCompletion writes a static shell completion script for programName to w. The
+// script shells out to "programName --__complete <index> <args...>" for the actual
+// candidate list, which runCompletionHook answers using the parser's live flag,
+// subparser, and choice-parameter state.
+func (p *Parser) GenerateCompletion(shell string, programName string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return p.generateBashCompletion(programName, w)
+	case "zsh":
+		return p.generateZshCompletion(programName, w)
+	case "fish":
+		return p.generateFishCompletion(programName, w)
+	default:
+		return fmt.Errorf("unsupported shell `%s' for completion", shell)
+	}
+}
+
+func (p *Parser) generateBashCompletion(programName string, w io.Writer) error {
+	funcName := "_" + sanitizeCompletionName(programName) + "_complete"
+
+	_, err := fmt.Fprintf(w, `%s() {
+    local index words
+    words=("${COMP_WORDS[@]:1}")
+    index=$((COMP_CWORD - 1))
+    IFS=$'\n' COMPREPLY=($(%s %s "$index" "${words[@]}"))
+}
+complete -F %s %s
+`, funcName, programName, completionSentinel, funcName, programName)
+
+	return err
+}
+
+func (p *Parser) generateZshCompletion(programName string, w io.Writer) error {
+	funcName := "_" + sanitizeCompletionName(programName)
+
+	_, err := fmt.Fprintf(w, `#compdef %s
+%s() {
+    local -a completions
+    completions=("${(@f)$(%s %s $((CURRENT - 2)) "${words[@]:1}")}")
+    compadd -- "${completions[@]}"
+}
+%s
+`, programName, funcName, programName, completionSentinel, funcName)
+
+	return err
+}
+
+func (p *Parser) generateFishCompletion(programName string, w io.Writer) error {
+	funcName := "__" + sanitizeCompletionName(programName) + "_complete"
+
+	_, err := fmt.Fprintf(w, `function %s
+    set -l tokens (commandline -opc)
+    set -l index (math (count $tokens) - 1)
+    %s %s $index $tokens[2..-1]
+end
+complete -c %s -f -a '(%s)'
+`, funcName, programName, completionSentinel, programName, funcName)
+
+	return err
+}
+
+func sanitizeCompletionName(programName string) string {
+	return strings.NewReplacer("-", "_", ".", "_", "/", "_").Replace(programName)
+}
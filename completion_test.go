@@ -0,0 +1,69 @@
+package goparse
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	p := NewParser()
+
+	var buf bytes.Buffer
+
+	if err := p.GenerateCompletion("powershell", "myprog", &buf); err == nil {
+		t.Fatal("expected an error for an unsupported shell")
+	}
+}
+
+func TestGenerateCompletionBash(t *testing.T) {
+	p := NewParser()
+	p.AddFlag("verbose", 'v', "be verbose", false)
+
+	var buf bytes.Buffer
+
+	if err := p.GenerateCompletion("bash", "myprog", &buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("expected a non-empty completion script")
+	}
+}
+
+func TestCompletionsSuggestsFlags(t *testing.T) {
+	p := NewParser()
+	p.AddFlag("verbose", 'v', "be verbose", false)
+
+	candidates := p.completions(0, []string{"--ver"})
+
+	if len(candidates) != 1 || candidates[0] != "--verbose" {
+		t.Errorf("expected [--verbose], got %v", candidates)
+	}
+}
+
+func TestCompletionsSkipsShortValueFlagArgument(t *testing.T) {
+	p := NewParser()
+	p.AddValueFlag("port", 'p', "a port", "port", "8080")
+	p.AddChoiceParameter("color", "a color", []paramOption{{name: "red"}, {name: "blue"}})
+
+	candidates := p.completions(2, []string{"-p", "8080", ""})
+
+	if len(candidates) != 2 {
+		t.Errorf("expected the choice options [red blue], got %v", candidates)
+	}
+}
+
+func TestCompletionsSuggestsVisibleChoiceOptionsOnly(t *testing.T) {
+	p := NewParser()
+	p.AddChoiceParameter("color", "a color", []paramOption{
+		{name: "red"},
+		{name: "blue"},
+		{name: "secret", hidden: true},
+	})
+
+	candidates := p.completions(0, []string{""})
+
+	if len(candidates) != 2 {
+		t.Errorf("expected 2 visible choice candidates, got %v", candidates)
+	}
+}
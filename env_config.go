@@ -0,0 +1,175 @@
+package goparse
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConfigFormat selects the file format LoadConfig expects to read.
+type ConfigFormat int
+
+const (
+	// ConfigFormatINI reads `[section]` headers and `key = value` pairs.
+	ConfigFormatINI ConfigFormat = iota
+	// ConfigFormatTOML reads the same flat `[section]` / `key = value` subset as
+	// ConfigFormatINI; it does not support TOML tables, arrays, or inline values.
+	ConfigFormatTOML
+	// ConfigFormatJSON reads a JSON object whose nested objects become sections.
+	ConfigFormatJSON
+)
+
+// resolveFallbackValue returns the value a flag should use when it was not passed on the
+// command line, checking the flag's environment variable and then the parser's config
+// values. The second return value is false if neither source has a value, in which case
+// the caller should fall back to the flag's default.
+func resolveFallbackValue(param valueFlagParam, configValues map[string]string) (string, bool) {
+	if param.envVar != "" {
+		if value, ok := os.LookupEnv(param.envVar); ok {
+			return value, true
+		}
+	}
+
+	if configValues != nil {
+		if value, ok := configValues[param.longName]; ok {
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+// Env marks longName, a flag previously registered with AddValueFlag or one of its typed
+// variants, as falling back to the environment variable varName when it was not passed on
+// the command line. Precedence is CLI argument, then env var, then config file, then the
+// flag's default value.
+func (p *Parser) Env(longName string, varName string) error {
+	for i := range p.valueFlagParams {
+		if p.valueFlagParams[i].longName == longName {
+			p.valueFlagParams[i].envVar = varName
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown value flag `%s'", longName)
+}
+
+// LoadConfig reads the file at path in the given format and uses it to fill in flag
+// values that are not passed on the command line or supplied by an Env variable. Keys
+// under a `[name]` section (INI/TOML) or nested object (JSON) are applied to the
+// subparser registered under that name; top-level keys are applied to p itself.
+func (p *Parser) LoadConfig(path string, format ConfigFormat) error {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return fmt.Errorf("read config file `%s': %w", path, err)
+	}
+
+	var sections map[string]map[string]string
+
+	switch format {
+	case ConfigFormatINI, ConfigFormatTOML:
+		sections, err = parseINIConfig(data)
+	case ConfigFormatJSON:
+		sections, err = parseJSONConfig(data)
+	default:
+		return fmt.Errorf("unknown config format %d", format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("parse config file `%s': %w", path, err)
+	}
+
+	p.applyConfigSections(sections)
+
+	return nil
+}
+
+// applyConfigSections distributes parsed config sections between p and its subparsers.
+// The unnamed section ("") belongs to p itself; every other section name is matched
+// against a registered subparser name.
+func (p *Parser) applyConfigSections(sections map[string]map[string]string) {
+	if values, ok := sections[""]; ok {
+		p.configValues = values
+	}
+
+	for name, values := range sections {
+		if name == "" {
+			continue
+		}
+
+		if subparser, ok := p.subparsers[name]; ok {
+			subparser.configValues = values
+			p.subparsers[name] = subparser
+		}
+	}
+}
+
+// parseINIConfig parses the flat `[section]` / `key = value` subset of INI (and TOML)
+// that goparse supports for config fallbacks. Lines starting with `;` or `#`, and blank
+// lines, are ignored.
+func parseINIConfig(data []byte) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{"": {}}
+	section := ""
+
+	for lineNumber, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+
+			if _, ok := sections[section]; !ok {
+				sections[section] = map[string]string{}
+			}
+
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected `key = value' or `[section]', found %q", lineNumber+1, line)
+		}
+
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		sections[section][key] = value
+	}
+
+	return sections, nil
+}
+
+// parseJSONConfig parses a JSON object into config sections. Scalar top-level fields
+// become part of the unnamed section; nested objects become sections named after their
+// key, matched against subparser names.
+func parseJSONConfig(data []byte) (map[string]map[string]string, error) {
+	var raw map[string]any
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	sections := map[string]map[string]string{"": {}}
+
+	for key, value := range raw {
+		if nested, ok := value.(map[string]any); ok {
+			section := map[string]string{}
+
+			for nestedKey, nestedValue := range nested {
+				section[nestedKey] = fmt.Sprintf("%v", nestedValue)
+			}
+
+			sections[key] = section
+			continue
+		}
+
+		sections[""][key] = fmt.Sprintf("%v", value)
+	}
+
+	return sections, nil
+}
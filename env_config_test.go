@@ -0,0 +1,105 @@
+package goparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValueFlagFallsBackToEnv(t *testing.T) {
+	p := NewParser()
+	p.AddValueFlag("port", 'p', "a port", "port", "8080")
+
+	if err := p.Env("port", "GOPARSE_TEST_PORT"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("GOPARSE_TEST_PORT", "9090")
+
+	values, errs := p.parseArgs([]string{})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["port"] != "9090" {
+		t.Errorf("expected port to fall back to env value, got %v", values["port"])
+	}
+}
+
+func TestValueFlagCLIOverridesEnv(t *testing.T) {
+	p := NewParser()
+	p.AddValueFlag("port", 'p', "a port", "port", "8080")
+
+	if err := p.Env("port", "GOPARSE_TEST_PORT_2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("GOPARSE_TEST_PORT_2", "9090")
+
+	values, errs := p.parseArgs([]string{"--port", "1234"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["port"] != "1234" {
+		t.Errorf("expected CLI argument to take precedence over env, got %v", values["port"])
+	}
+}
+
+func TestLoadConfigINIFillsInDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ini")
+
+	if err := os.WriteFile(path, []byte("port = 7070\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := NewParser()
+	p.AddValueFlag("port", 'p', "a port", "port", "8080")
+
+	if err := p.LoadConfig(path, ConfigFormatINI); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values, errs := p.parseArgs([]string{})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["port"] != "7070" {
+		t.Errorf("expected port from config file, got %v", values["port"])
+	}
+}
+
+func TestEnvOutranksConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.ini")
+
+	if err := os.WriteFile(path, []byte("port = 7070\n"), 0o644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := NewParser()
+	p.AddValueFlag("port", 'p', "a port", "port", "8080")
+
+	if err := p.LoadConfig(path, ConfigFormatINI); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := p.Env("port", "GOPARSE_TEST_PORT_3"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Setenv("GOPARSE_TEST_PORT_3", "9090")
+
+	values, errs := p.parseArgs([]string{})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["port"] != "9090" {
+		t.Errorf("expected env to outrank config file, got %v", values["port"])
+	}
+}
@@ -13,6 +13,15 @@ type flagParam struct {
 	shortName    rune
 	description  string
 	setByDefault bool
+
+	// repeatable is set by AddCountFlag. A repeatable flag's value in the values map is
+	// an int counting how many times it was passed (e.g. "-vvv" or "-v -v -v" yields 3)
+	// instead of a bool.
+	repeatable bool
+
+	// callback, if set by AddFlagWithCallback, is invoked with the flag's new value the
+	// moment it is consumed; a non-nil error aborts parsing.
+	callback func(value any) error
 }
 
 type valueFlagParam struct {
@@ -21,6 +30,27 @@ type valueFlagParam struct {
 	description  string
 	valueName    string
 	defaultValue string
+
+	// defaultAny, typeName, and parse are set by AddTypedFlag, AddIntFlag, AddFloatFlag,
+	// AddDurationFlag, and AddBytesFlag to convert the raw
+	// argument into a typed value. They are nil/zero for flags added with AddValueFlag,
+	// which keep storing the argument verbatim as a string.
+	defaultAny any
+	typeName   string
+	parse      func(string) (any, error)
+
+	// envVar is set by Env and names an environment variable to fall back to when the
+	// flag was not passed on the command line, before falling back to defaultValue.
+	envVar string
+
+	// callback, if set by AddValueFlagWithCallback, is invoked with the flag's new value
+	// the moment it is consumed; a non-nil error aborts parsing.
+	callback func(value any) error
+
+	// repeatableValue is set by AddRepeatableValueFlag. A repeatable value flag's value
+	// in the values map is a []string accumulating every occurrence (e.g. "-D foo=1 -D
+	// bar=2" yields []string{"foo=1", "bar=2"}) instead of a single string.
+	repeatableValue bool
 }
 
 type paramOption struct {
@@ -33,6 +63,10 @@ type positionalParam struct {
 	description string
 	options     []paramOption
 	minCount    int
+
+	// callback, if set by AddParameterWithCallback or SetListParameterWithCallback, is
+	// invoked with each value the moment it is consumed; a non-nil error aborts parsing.
+	callback func(value any) error
 }
 
 type Parser struct {
@@ -43,6 +77,11 @@ type Parser struct {
 	listParam         *positionalParam
 	subparserArgument string
 	subparsers        map[string]Parser
+
+	// configValues holds values loaded by LoadConfig for this parser's own section,
+	// keyed by flag long name. It sits below env vars and above defaultValue/defaultAny
+	// in precedence.
+	configValues map[string]string
 }
 
 func NewParser() Parser {
@@ -81,8 +120,29 @@ func (p *Parser) marshal(values map[string]any) ([]string, error) {
 	for _, param := range p.valueFlagParams {
 		value, exists := values[param.longName]
 
+		if param.repeatableValue {
+			occurrences, ok := value.([]string)
+
+			if exists && !ok {
+				return nil, fmt.Errorf("expected value of type []string for repeatable value flag `%s' but found %T", param.longName, value)
+			}
+
+			for _, occurrence := range occurrences {
+				arguments = append(arguments, "--"+param.longName, shellescape.Quote(occurrence))
+			}
+
+			continue
+		}
+
 		if exists {
-			val := fmt.Sprintf("%s", value)
+			var val string
+
+			if param.parse != nil {
+				val = fmt.Sprintf("%v", value)
+			} else {
+				val = fmt.Sprintf("%s", value)
+			}
+
 			arguments = append(arguments, "--"+param.longName, shellescape.Quote(val))
 		} else {
 			arguments = append(arguments, "--"+param.longName, shellescape.Quote(param.defaultValue))
@@ -94,6 +154,20 @@ func (p *Parser) marshal(values map[string]any) ([]string, error) {
 	for _, param := range p.flagParams {
 		value, exists := values[param.longName]
 
+		if param.repeatable {
+			count, ok := value.(int)
+
+			if exists && !ok {
+				return nil, fmt.Errorf("expected value of type int for count flag `%s' but found %T", param.longName, value)
+			}
+
+			for i := 0; i < count; i++ {
+				arguments = append(arguments, "--"+param.longName)
+			}
+
+			continue
+		}
+
 		if exists {
 			isSet, ok := value.(bool)
 
@@ -203,6 +277,27 @@ func (p *Parser) Marshal(executable string, values map[string]any) (string, erro
 		arguments = append(arguments, args...)
 	}
 
+	// Marshal the passthrough sentinel, if present.
+
+	if passthrough, exists := values["--"]; exists {
+		switch val := passthrough.(type) {
+		case []string:
+			arguments = append(arguments, "--")
+
+			for _, value := range val {
+				arguments = append(arguments, shellescape.Quote(value))
+			}
+		case []any:
+			arguments = append(arguments, "--")
+
+			for _, value := range val {
+				arguments = append(arguments, shellescape.Quote(fmt.Sprintf("%s", value)))
+			}
+		default:
+			return "", fmt.Errorf("expected value of type []any or []string for passthrough arguments but found %T", passthrough)
+		}
+	}
+
 	return strings.Join(arguments, " "), nil
 }
 
@@ -229,6 +324,35 @@ func (p *Parser) AddValueFlag(longName string, shortName rune, description strin
 	p.valueFlagParams = append(p.valueFlagParams, c)
 }
 
+// AddCountFlag registers a flag whose value is an int counting how many times it was
+// passed, e.g. "-vvv" or "-v -v -v" both yield 3. Its value in the values map defaults to 0.
+func (p *Parser) AddCountFlag(longName string, shortName rune, description string) {
+	c := flagParam{
+		longName:    longName,
+		shortName:   shortName,
+		description: description,
+		repeatable:  true,
+	}
+
+	p.flagParams = append(p.flagParams, c)
+}
+
+// AddRepeatableValueFlag registers a value flag that may be passed more than once, with
+// each occurrence accumulating into a []string in the order passed, e.g. "-D foo=1 -D
+// bar=2" yields []string{"foo=1", "bar=2"}. Its value in the values map defaults to an
+// empty []string.
+func (p *Parser) AddRepeatableValueFlag(longName string, shortName rune, description string, valueName string) {
+	c := valueFlagParam{
+		longName:        longName,
+		shortName:       shortName,
+		description:     description,
+		valueName:       strings.ToUpper(valueName),
+		repeatableValue: true,
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
 func (p *Parser) AddParameter(name string, description string) {
 	c := positionalParam{
 		name:        name,
@@ -330,11 +454,38 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 	// Set defaults
 
 	for _, flagConfig := range p.flagParams {
-		values[flagConfig.longName] = flagConfig.setByDefault
+		if flagConfig.repeatable {
+			values[flagConfig.longName] = 0
+		} else {
+			values[flagConfig.longName] = flagConfig.setByDefault
+		}
 	}
 
 	for _, flagValueConfig := range p.valueFlagParams {
-		values[flagValueConfig.longName] = flagValueConfig.defaultValue
+		if flagValueConfig.repeatableValue {
+			values[flagValueConfig.longName] = []string{}
+			continue
+		}
+
+		raw, hasFallback := resolveFallbackValue(flagValueConfig, p.configValues)
+
+		switch {
+		case hasFallback && flagValueConfig.parse != nil:
+			parsed, err := flagValueConfig.parse(raw)
+
+			if err != nil {
+				errors = append(errors, fmt.Errorf("invalid value \"%s\" for flag --%s: expected %s", raw, flagValueConfig.longName, flagValueConfig.typeName))
+				values[flagValueConfig.longName] = flagValueConfig.defaultAny
+			} else {
+				values[flagValueConfig.longName] = parsed
+			}
+		case hasFallback:
+			values[flagValueConfig.longName] = raw
+		case flagValueConfig.parse != nil:
+			values[flagValueConfig.longName] = flagValueConfig.defaultAny
+		default:
+			values[flagValueConfig.longName] = flagValueConfig.defaultValue
+		}
 	}
 
 	// Populate values
@@ -346,6 +497,12 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 			break
 		}
 
+		if arg == "--" {
+			values["--"] = append([]string{}, p.args...)
+			p.args = nil
+			break
+		}
+
 		isLongFlag := strings.HasPrefix(arg, "--")
 		isShortFlag := strings.HasPrefix(arg, "-")
 		isParameterArg := currentArgPos < len(p.positionalParams)
@@ -360,8 +517,26 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 			} else {
 				for _, flagConfig := range p.flagParams {
 					if flagConfig.longName == longName {
-						values[flagConfig.longName] = !flagConfig.setByDefault
+						var newValue any
+
+						if flagConfig.repeatable {
+							count, _ := values[flagConfig.longName].(int)
+							count++
+							values[flagConfig.longName] = count
+							newValue = count
+						} else {
+							newValue = !flagConfig.setByDefault
+							values[flagConfig.longName] = newValue
+						}
+
 						found = true
+
+						if flagConfig.callback != nil {
+							if cbErr := flagConfig.callback(newValue); cbErr != nil {
+								return values, append(errors, cbErr)
+							}
+						}
+
 						break
 					}
 				}
@@ -369,19 +544,45 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 				for _, flagConfig := range p.valueFlagParams {
 					if flagConfig.longName == longName {
 						flagValue, ok := p.popArg()
+						var parsedValue any
+						consumed := false
 
 						if !ok {
 							errors = append(errors, fmt.Errorf("missing value for flag `%s'", longName))
+						} else if flagConfig.repeatableValue {
+							existing, _ := values[flagConfig.longName].([]string)
+							values[flagConfig.longName] = append(existing, flagValue)
+							parsedValue = flagValue
+							consumed = true
+						} else if flagConfig.parse != nil {
+							parsed, err := flagConfig.parse(flagValue)
+
+							if err != nil {
+								errors = append(errors, fmt.Errorf("invalid value \"%s\" for flag --%s: expected %s", flagValue, flagConfig.longName, flagConfig.typeName))
+							} else {
+								values[flagConfig.longName] = parsed
+								parsedValue = parsed
+								consumed = true
+							}
+						} else {
+							values[flagConfig.longName] = flagValue
+							parsedValue = flagValue
+							consumed = true
 						}
 
-						values[flagConfig.longName] = flagValue
 						found = true
+
+						if consumed && flagConfig.callback != nil {
+							if cbErr := flagConfig.callback(parsedValue); cbErr != nil {
+								return values, append(errors, cbErr)
+							}
+						}
 					}
 				}
 			}
 
 			if !found {
-				errors = append(errors, fmt.Errorf("unknown flag `--%s'", longName))
+				errors = append(errors, fmt.Errorf("unknown flag `--%s'%s", longName, didYouMean(longName, p.longFlagCandidates())))
 			}
 
 			continue
@@ -397,8 +598,26 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 				} else {
 					for _, flagConfig := range p.flagParams {
 						if flagConfig.shortName == shortName {
-							values[flagConfig.longName] = !flagConfig.setByDefault
+							var newValue any
+
+							if flagConfig.repeatable {
+								count, _ := values[flagConfig.longName].(int)
+								count++
+								values[flagConfig.longName] = count
+								newValue = count
+							} else {
+								newValue = !flagConfig.setByDefault
+								values[flagConfig.longName] = newValue
+							}
+
 							found = true
+
+							if flagConfig.callback != nil {
+								if cbErr := flagConfig.callback(newValue); cbErr != nil {
+									return values, append(errors, cbErr)
+								}
+							}
+
 							break
 						}
 					}
@@ -406,20 +625,47 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 					for _, flagConfig := range p.valueFlagParams {
 						if flagConfig.shortName == shortName {
 							flagValue, ok := p.popArg()
+							var parsedValue any
+							consumed := false
 
 							if !ok {
 								errors = append(errors, fmt.Errorf("missing value for flag `%c'", shortName))
+							} else if flagConfig.repeatableValue {
+								existing, _ := values[flagConfig.longName].([]string)
+								values[flagConfig.longName] = append(existing, flagValue)
+								parsedValue = flagValue
+								consumed = true
+							} else if flagConfig.parse != nil {
+								parsed, err := flagConfig.parse(flagValue)
+
+								if err != nil {
+									errors = append(errors, fmt.Errorf("invalid value \"%s\" for flag --%s: expected %s", flagValue, flagConfig.longName, flagConfig.typeName))
+								} else {
+									values[flagConfig.longName] = parsed
+									parsedValue = parsed
+									consumed = true
+								}
+							} else {
+								values[flagConfig.longName] = flagValue
+								parsedValue = flagValue
+								consumed = true
 							}
 
-							values[flagConfig.longName] = flagValue
 							found = true
+
+							if consumed && flagConfig.callback != nil {
+								if cbErr := flagConfig.callback(parsedValue); cbErr != nil {
+									return values, append(errors, cbErr)
+								}
+							}
+
 							break
 						}
 					}
 				}
 
 				if !found {
-					errors = append(errors, fmt.Errorf("unknown flag `-%c'", shortName))
+					errors = append(errors, fmt.Errorf("unknown flag `-%c'%s", shortName, didYouMean(string(shortName), p.shortFlagCandidates())))
 				}
 			}
 
@@ -433,7 +679,7 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 				subparser, ok := p.subparsers[arg]
 
 				if !ok {
-					errors = append(errors, fmt.Errorf("bad argument \"%s\" for parameter `%s'", arg, parameterConfig.name))
+					errors = append(errors, fmt.Errorf("bad argument \"%s\" for parameter `%s'%s", arg, parameterConfig.name, didYouMean(arg, p.subparserCandidates())))
 				}
 
 				values[parameterConfig.name] = arg
@@ -450,17 +696,43 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 				return values, errors
 			}
 
-			for _, option := range parameterConfig.options {
-				if option.name == arg {
-					errors = append(errors, fmt.Errorf("bad argument \"%s\" for parameter `%s'", arg, parameterConfig.name))
-					break
+			if len(parameterConfig.options) > 0 {
+				matched := false
+
+				for _, option := range parameterConfig.options {
+					if option.name == arg {
+						matched = true
+						break
+					}
+				}
+
+				if !matched {
+					var candidates []string
+
+					for _, o := range parameterConfig.options {
+						candidates = append(candidates, o.name)
+					}
+
+					errors = append(errors, fmt.Errorf("bad argument \"%s\" for parameter `%s'%s", arg, parameterConfig.name, didYouMean(arg, candidates)))
 				}
 			}
 
 			values[parameterConfig.name] = arg
 			currentArgPos += 1
+
+			if parameterConfig.callback != nil {
+				if cbErr := parameterConfig.callback(arg); cbErr != nil {
+					return values, append(errors, cbErr)
+				}
+			}
 		} else if hasListParameterArg {
 			listValues = append(listValues, arg)
+
+			if p.listParam.callback != nil {
+				if cbErr := p.listParam.callback(arg); cbErr != nil {
+					return values, append(errors, cbErr)
+				}
+			}
 		} else {
 			errors = append(errors, fmt.Errorf("received unexpected argument \"%s\"", arg))
 		}
@@ -493,6 +765,12 @@ func (p *Parser) parseArgs(args []string) (map[string]any, []error) {
 
 func (p *Parser) ParseArgs() (map[string]any, []error) {
 	osArgs := os.Args[1:]
+
+	if len(osArgs) > 0 && osArgs[0] == completionSentinel {
+		p.runCompletionHook(osArgs[1:])
+		os.Exit(0)
+	}
+
 	args, errors := p.parseArgs(osArgs)
 
 	if _, ok := args["help"]; ok {
@@ -538,11 +816,19 @@ func (p *Parser) getParamString(subparserArg string) string {
 	usage := ""
 
 	for _, valueFlagArg := range p.valueFlagParams {
-		usage += fmt.Sprintf(" [-%c, --%s %s]", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		if valueFlagArg.repeatableValue {
+			usage += fmt.Sprintf(" [-%c, --%s %s]...", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		} else {
+			usage += fmt.Sprintf(" [-%c, --%s %s]", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		}
 	}
 
 	for _, flagArg := range p.flagParams {
-		usage += fmt.Sprintf(" [-%c, --%s]", flagArg.shortName, flagArg.longName)
+		if flagArg.repeatable {
+			usage += fmt.Sprintf(" [-%c...]", flagArg.shortName)
+		} else {
+			usage += fmt.Sprintf(" [-%c, --%s]", flagArg.shortName, flagArg.longName)
+		}
 	}
 
 	for _, parameter := range p.positionalParams {
@@ -581,7 +867,14 @@ func (p *Parser) getFlagDescriptions(subparserArg string) string {
 	maxPrefixLen := 0
 
 	for i, valueFlagArg := range p.valueFlagParams {
-		prefix := fmt.Sprintf("-%c, --%s %s", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		var prefix string
+
+		if valueFlagArg.repeatableValue {
+			prefix = fmt.Sprintf("-%c, --%s %s...", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		} else {
+			prefix = fmt.Sprintf("-%c, --%s %s", valueFlagArg.shortName, valueFlagArg.longName, valueFlagArg.valueName)
+		}
+
 		prefixLen := len(prefix)
 
 		if prefixLen > maxPrefixLen {
@@ -592,7 +885,14 @@ func (p *Parser) getFlagDescriptions(subparserArg string) string {
 	}
 
 	for i, flagArg := range p.flagParams {
-		prefix := fmt.Sprintf("-%c, --%s", flagArg.shortName, flagArg.longName)
+		var prefix string
+
+		if flagArg.repeatable {
+			prefix = fmt.Sprintf("-%c...", flagArg.shortName)
+		} else {
+			prefix = fmt.Sprintf("-%c, --%s", flagArg.shortName, flagArg.longName)
+		}
+
 		prefixLen := len(prefix)
 
 		if prefixLen > maxPrefixLen {
@@ -0,0 +1,65 @@
+package goparse
+
+import "testing"
+
+func TestAddCountFlagCountsClusteredOccurrences(t *testing.T) {
+	p := NewParser()
+	p.AddCountFlag("verbose", 'v', "be verbose")
+
+	values, errs := p.parseArgs([]string{"-vvv"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["verbose"] != 3 {
+		t.Errorf("expected a count of 3, got %v", values["verbose"])
+	}
+}
+
+func TestAddCountFlagCountsRepeatedLongFlag(t *testing.T) {
+	p := NewParser()
+	p.AddCountFlag("verbose", 'v', "be verbose")
+
+	values, errs := p.parseArgs([]string{"--verbose", "--verbose"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["verbose"] != 2 {
+		t.Errorf("expected a count of 2, got %v", values["verbose"])
+	}
+}
+
+func TestAddRepeatableValueFlagAccumulates(t *testing.T) {
+	p := NewParser()
+	p.AddRepeatableValueFlag("define", 'D', "a define", "kv")
+
+	values, errs := p.parseArgs([]string{"-D", "foo=1", "-D", "bar=2"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	defines, ok := values["define"].([]string)
+
+	if !ok || len(defines) != 2 || defines[0] != "foo=1" || defines[1] != "bar=2" {
+		t.Errorf("expected [foo=1 bar=2], got %v", values["define"])
+	}
+}
+
+func TestMarshalReemitsCountFlag(t *testing.T) {
+	p := NewParser()
+	p.AddCountFlag("verbose", 'v', "be verbose")
+
+	command, err := p.Marshal("prog", map[string]any{"verbose": 2})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if command != "prog --verbose --verbose" {
+		t.Errorf("expected \"prog --verbose --verbose\", got %q", command)
+	}
+}
@@ -0,0 +1,154 @@
+package goparse
+
+import (
+	"fmt"
+	"sort"
+)
+
+// levenshteinDistance returns the Levenshtein edit distance between a and b using the
+// standard two-row dynamic-programming variant.
+func levenshteinDistance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			deletion := prev[j] + 1
+			insertion := curr[j-1] + 1
+			substitution := prev[j-1] + cost
+
+			curr[j] = min(deletion, min(insertion, substitution))
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+// suggestionThreshold returns the maximum edit distance a candidate may have from input
+// to still be considered a "did you mean" suggestion for it.
+func suggestionThreshold(input string) int {
+	threshold := len(input) / 3
+
+	if threshold < 2 {
+		return 2
+	}
+
+	return threshold
+}
+
+// closestMatches returns up to the top-2 candidates closest to input by edit distance,
+// excluding any outside suggestionThreshold(input).
+func closestMatches(input string, candidates []string) []string {
+	threshold := suggestionThreshold(input)
+
+	type scoredCandidate struct {
+		name     string
+		distance int
+	}
+
+	var scored []scoredCandidate
+
+	for _, candidate := range candidates {
+		distance := levenshteinDistance(input, candidate)
+
+		if distance <= threshold {
+			scored = append(scored, scoredCandidate{candidate, distance})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].distance != scored[j].distance {
+			return scored[i].distance < scored[j].distance
+		}
+
+		return scored[i].name < scored[j].name
+	})
+
+	var matches []string
+
+	for i := 0; i < len(scored) && i < 2; i++ {
+		matches = append(matches, scored[i].name)
+	}
+
+	return matches
+}
+
+// didYouMean formats the "did you mean" suffix for an error message, or returns an empty
+// string if there are no close-enough candidates.
+func didYouMean(input string, candidates []string) string {
+	matches := closestMatches(input, candidates)
+
+	switch len(matches) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(" (did you mean `%s'?)", matches[0])
+	default:
+		return fmt.Sprintf(" (did you mean `%s' or `%s'?)", matches[0], matches[1])
+	}
+}
+
+// longFlagCandidates returns the long names of every flag and value flag registered on p,
+// used as candidates for "did you mean" suggestions on an unrecognized long flag.
+func (p *Parser) longFlagCandidates() []string {
+	var candidates []string
+
+	for _, flagConfig := range p.flagParams {
+		candidates = append(candidates, flagConfig.longName)
+	}
+
+	for _, flagConfig := range p.valueFlagParams {
+		candidates = append(candidates, flagConfig.longName)
+	}
+
+	return candidates
+}
+
+// shortFlagCandidates returns every registered short flag name, as single-rune strings,
+// used as candidates for "did you mean" suggestions on an unrecognized short flag.
+func (p *Parser) shortFlagCandidates() []string {
+	var candidates []string
+
+	for _, flagConfig := range p.flagParams {
+		if flagConfig.shortName != 0 {
+			candidates = append(candidates, string(flagConfig.shortName))
+		}
+	}
+
+	for _, flagConfig := range p.valueFlagParams {
+		if flagConfig.shortName != 0 {
+			candidates = append(candidates, string(flagConfig.shortName))
+		}
+	}
+
+	return candidates
+}
+
+// subparserCandidates returns the registered subparser names, used as candidates for
+// "did you mean" suggestions on an unrecognized subcommand.
+func (p *Parser) subparserCandidates() []string {
+	var candidates []string
+
+	for name := range p.subparsers {
+		candidates = append(candidates, name)
+	}
+
+	return candidates
+}
@@ -0,0 +1,107 @@
+package goparse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChoiceParameterAcceptsValidValue(t *testing.T) {
+	p := NewParser()
+	p.AddChoiceParameter("color", "a color", []paramOption{{name: "red"}, {name: "blue"}})
+
+	values, errs := p.parseArgs([]string{"red"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["color"] != "red" {
+		t.Errorf("expected color to be \"red\", got %v", values["color"])
+	}
+}
+
+func TestChoiceParameterRejectsInvalidValue(t *testing.T) {
+	p := NewParser()
+	p.AddChoiceParameter("color", "a color", []paramOption{{name: "red"}, {name: "blue"}})
+
+	_, errs := p.parseArgs([]string{"gren"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error for an invalid choice value, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestUnknownFlagSuggestsClosestMatch(t *testing.T) {
+	p := NewParser()
+	p.AddFlag("verbose", 'v', "be verbose", false)
+
+	_, errs := p.parseArgs([]string{"--verbos"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+	}
+
+	if !strings.Contains(errs[0].Error(), "did you mean `verbose'?") {
+		t.Errorf("expected a suggestion of `verbose', got %q", errs[0].Error())
+	}
+}
+
+func TestUnknownSubparserSuggestsClosestMatch(t *testing.T) {
+	p := NewParser()
+	p.Subparse("action", "the action to take", SubparserMap{
+		"build": func(subparser *Parser) {},
+		"run":   func(subparser *Parser) {},
+	})
+
+	_, errs := p.parseArgs([]string{"buidl"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+	}
+
+	if !strings.Contains(errs[0].Error(), "did you mean `build'?") {
+		t.Errorf("expected a suggestion of `build', got %q", errs[0].Error())
+	}
+}
+
+func TestUnknownSubparserSuggestionIsDeterministic(t *testing.T) {
+	newParser := func() Parser {
+		p := NewParser()
+		p.Subparse("action", "the action to take", SubparserMap{
+			"cat": func(subparser *Parser) {},
+			"car": func(subparser *Parser) {},
+		})
+		return p
+	}
+
+	first := newParser()
+	_, errs := first.parseArgs([]string{"cax"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+	}
+
+	want := errs[0].Error()
+
+	for i := 0; i < 50; i++ {
+		p := newParser()
+		_, errs := p.parseArgs([]string{"cax"})
+
+		if len(errs) != 1 {
+			t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+		}
+
+		if errs[0].Error() != want {
+			t.Fatalf("expected a deterministic suggestion, got %q and %q", want, errs[0].Error())
+		}
+	}
+}
+
+func TestClosestMatches(t *testing.T) {
+	candidates := []string{"verbose", "version", "output"}
+	matches := closestMatches("verbos", candidates)
+
+	if len(matches) == 0 || matches[0] != "verbose" {
+		t.Errorf("expected \"verbose\" to be the closest match, got %v", matches)
+	}
+}
@@ -0,0 +1,150 @@
+package goparse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// byteUnits maps the suffixes accepted by AddBytesFlag to their multiplier, checked
+// longest-first so that, e.g., "MiB" is not mistaken for "B".
+var byteUnits = []struct {
+	suffix     string
+	multiplier int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"TB", 1e12},
+	{"GB", 1e9},
+	{"MB", 1e6},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// parseBytes parses a byte count such as "512", "10MB", or "2.5GiB" into a number of bytes.
+func parseBytes(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+
+	for _, unit := range byteUnits {
+		if strings.HasSuffix(strings.ToUpper(trimmed), strings.ToUpper(unit.suffix)) {
+			numeric := strings.TrimSpace(trimmed[:len(trimmed)-len(unit.suffix)])
+
+			if numeric == "" {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(numeric, 64)
+
+			if err != nil {
+				return 0, err
+			}
+
+			return int64(value * float64(unit.multiplier)), nil
+		}
+	}
+
+	return strconv.ParseInt(trimmed, 10, 64)
+}
+
+// AddTypedFlag registers a value flag whose raw argument is converted to T by parse
+// before being stored in the values map returned by ParseArgs, and rendered back to its
+// canonical string form by Marshal via fmt.Sprintf("%v", ...).
+//
+// AddIntFlag, AddFloatFlag, AddDurationFlag, and AddBytesFlag cover the common cases; they
+// build their flags directly rather than through AddTypedFlag, and report the type names
+// "int", "float", "duration", and "bytes" in parse errors. AddTypedFlag is a package-level
+// function rather than a method because Go does not support generic methods on non-generic
+// types.
+func AddTypedFlag[T any](p *Parser, longName string, shortName rune, description string, defaultValue T, parse func(string) (T, error)) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(longName),
+		defaultValue: fmt.Sprintf("%v", defaultValue),
+		defaultAny:   defaultValue,
+		typeName:     fmt.Sprintf("%T", defaultValue),
+		parse: func(s string) (any, error) {
+			return parse(s)
+		},
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
+// AddIntFlag registers a value flag whose argument is parsed as a base-10 integer.
+func AddIntFlag(p *Parser, longName string, shortName rune, description string, defaultValue int) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(longName),
+		defaultValue: strconv.Itoa(defaultValue),
+		defaultAny:   defaultValue,
+		typeName:     "int",
+		parse: func(s string) (any, error) {
+			return strconv.Atoi(s)
+		},
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
+// AddFloatFlag registers a value flag whose argument is parsed as a float64.
+func AddFloatFlag(p *Parser, longName string, shortName rune, description string, defaultValue float64) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(longName),
+		defaultValue: strconv.FormatFloat(defaultValue, 'g', -1, 64),
+		defaultAny:   defaultValue,
+		typeName:     "float",
+		parse: func(s string) (any, error) {
+			return strconv.ParseFloat(s, 64)
+		},
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
+// AddDurationFlag registers a value flag whose argument is parsed with time.ParseDuration,
+// e.g. "250ms", "1h30m".
+func AddDurationFlag(p *Parser, longName string, shortName rune, description string, defaultValue time.Duration) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(longName),
+		defaultValue: defaultValue.String(),
+		defaultAny:   defaultValue,
+		typeName:     "duration",
+		parse: func(s string) (any, error) {
+			return time.ParseDuration(s)
+		},
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
+
+// AddBytesFlag registers a value flag whose argument is parsed as a byte count, accepting
+// suffixes such as "KB", "MiB", and "GB" in addition to a bare number of bytes.
+func AddBytesFlag(p *Parser, longName string, shortName rune, description string, defaultValue int64) {
+	c := valueFlagParam{
+		longName:     longName,
+		shortName:    shortName,
+		description:  description,
+		valueName:    strings.ToUpper(longName),
+		defaultValue: strconv.FormatInt(defaultValue, 10),
+		defaultAny:   defaultValue,
+		typeName:     "bytes",
+		parse: func(s string) (any, error) {
+			return parseBytes(s)
+		},
+	}
+
+	p.valueFlagParams = append(p.valueFlagParams, c)
+}
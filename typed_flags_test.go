@@ -0,0 +1,77 @@
+package goparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddIntFlagParsesValue(t *testing.T) {
+	p := NewParser()
+	AddIntFlag(&p, "count", 'c', "a count", 0)
+
+	values, errs := p.parseArgs([]string{"--count", "42"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["count"] != 42 {
+		t.Errorf("expected count to be 42, got %v", values["count"])
+	}
+}
+
+func TestAddIntFlagRejectsBadValue(t *testing.T) {
+	p := NewParser()
+	AddIntFlag(&p, "count", 'c', "a count", 0)
+
+	_, errs := p.parseArgs([]string{"--count", "nope"})
+
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestAddDurationFlagDefault(t *testing.T) {
+	p := NewParser()
+	AddDurationFlag(&p, "timeout", 't', "a timeout", 5*time.Second)
+
+	values, errs := p.parseArgs([]string{})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["timeout"] != 5*time.Second {
+		t.Errorf("expected default timeout of 5s, got %v", values["timeout"])
+	}
+}
+
+func TestAddBytesFlagParsesSuffix(t *testing.T) {
+	p := NewParser()
+	AddBytesFlag(&p, "size", 's', "a size", 0)
+
+	values, errs := p.parseArgs([]string{"--size", "10MB"})
+
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+
+	if values["size"] != int64(10e6) {
+		t.Errorf("expected size of 10,000,000 bytes, got %v", values["size"])
+	}
+}
+
+func TestMarshalRendersTypedValue(t *testing.T) {
+	p := NewParser()
+	AddIntFlag(&p, "count", 'c', "a count", 0)
+
+	command, err := p.Marshal("prog", map[string]any{"count": 7})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if command != "prog --count 7" {
+		t.Errorf("expected \"prog --count 7\", got %q", command)
+	}
+}